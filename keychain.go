@@ -1,20 +1,27 @@
 package keycheck
 
 import (
+	"context"
 	"fmt"
 	"slices"
+	"sync"
 )
 
 type (
-	ErrInvalidBitwiseID BitwiseID
-	ErrNoValidatorExist struct{}
-	ErrNilReceiver      struct{}
+	ErrInvalidBitwiseID   BitwiseID
+	ErrInvalidParallelism int
+	ErrNoValidatorExist   struct{}
+	ErrNilReceiver        struct{}
 )
 
 func (err ErrInvalidBitwiseID) Error() string {
 	return fmt.Sprintf("keycheck: invalid bitwise operator ID %d", uint8(err))
 }
 
+func (err ErrInvalidParallelism) Error() string {
+	return fmt.Sprintf("keycheck: invalid parallelism %d, must be >= 1", int(err))
+}
+
 func (err ErrNoValidatorExist) Error() string {
 	return "keycheck: no validators registered"
 }
@@ -120,16 +127,23 @@ func (bid BitwiseID) IsValid() bool {
 type KeyChain[T any] interface {
 	DelValidator(label string) error
 	GetValidator(label string) (Status, func(a T) (bool, error), error)
-	Reset()
+	Reset() error
+	Marshal(marshal func(v any) ([]byte, error)) ([]byte, error)
 	SetCondition(condition BitwiseID) error
+	SetParallelism(n int) error
 	SetValidator(status Status, fn func(a T) (bool, error)) error
+	SetValidatorContext(status Status, fn func(ctx context.Context, a T) (bool, error)) error
 	Validate(data T, defaultStatus StatusGetter) (StatusGetter, bool, []error)
+	ValidateContext(ctx context.Context, data T, defaultStatus StatusGetter) (StatusGetter, bool, []error)
 }
 
 type keyChain[T any] struct {
-	validators validatorsMap[T]
-	condition  BitwiseID
-	order      []string
+	mu          sync.RWMutex
+	validators  validatorsMap[T]
+	condition   BitwiseID
+	order       []string
+	parallelism int
+	refs        map[string]string
 }
 
 // NewKeyChain creates and returns a new KeyChain instance with a specified
@@ -140,9 +154,10 @@ func NewKeyChain[T any](condition BitwiseID) (KeyChain[T], error) {
 		return nil, ErrInvalidBitwiseID(condition)
 	}
 	return &keyChain[T]{
-		validators: validatorsMap[T]{},
-		condition:  condition,
-		order:      []string{},
+		validators:  validatorsMap[T]{},
+		condition:   condition,
+		order:       []string{},
+		parallelism: 1,
 	}, nil
 }
 
@@ -152,6 +167,8 @@ func (kc *keyChain[T]) DelValidator(label string) error {
 	if kc == nil {
 		return ErrNilReceiver{}
 	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
 	if kc.validators == nil {
 		return ErrNoValidatorExist{}
 	}
@@ -171,6 +188,8 @@ func (kc *keyChain[T]) GetValidator(id string) (Status, func(a T) (bool, error),
 	if kc == nil {
 		return Status{}, nil, ErrNilReceiver{}
 	}
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
 	if kc.validators == nil {
 		return Status{}, nil, ErrNoValidatorExist{}
 	}
@@ -184,6 +203,8 @@ func (kc *keyChain[T]) SetValidator(status Status, fn func(a T) (bool, error)) e
 	if kc == nil {
 		return ErrNilReceiver{}
 	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
 	if kc.validators == nil {
 		kc.validators = validatorsMap[T]{}
 	}
@@ -191,6 +212,7 @@ func (kc *keyChain[T]) SetValidator(status Status, fn func(a T) (bool, error)) e
 		kc.order = append(kc.order, status.ID)
 	}
 	kc.validators.Set(status, fn)
+	delete(kc.refs, status.ID)
 	return nil
 }
 
@@ -203,10 +225,83 @@ func (kc *keyChain[T]) SetCondition(condition BitwiseID) error {
 	if !condition.IsValid() {
 		return ErrInvalidBitwiseID(condition)
 	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
 	kc.condition = condition
 	return nil
 }
 
+// Marshal encodes the keychain's condition and validators into the same
+// document shape accepted by LoadKeyChain, using marshal (e.g.
+// json.Marshal) to produce the final bytes. Validator functions are not
+// serializable, so only each entry's ID, Details and, if the validator
+// was registered through LoadKeyChain, the registry ref it came from are
+// emitted; validators set directly via SetValidator have an empty ref.
+func (kc *keyChain[T]) Marshal(marshal func(v any) ([]byte, error)) ([]byte, error) {
+	if kc == nil {
+		return nil, ErrNilReceiver{}
+	}
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
+	if !kc.condition.IsValid() {
+		return nil, ErrInvalidBitwiseID(kc.condition)
+	}
+	doc := keyChainDocument{
+		Condition:  conditionLabels[kc.condition],
+		Validators: make([]validatorDocument, 0, len(kc.order)),
+	}
+	for _, id := range kc.order {
+		status, fn := kc.validators.Get(id)
+		if fn == nil {
+			continue
+		}
+		doc.Validators = append(doc.Validators, validatorDocument{
+			ID:      status.ID,
+			Ref:     kc.refs[id],
+			Details: status.Details,
+		})
+	}
+	return marshal(doc)
+}
+
+// SetValidatorContext adds or updates a context-aware validator function
+// for a given status, replacing any validator (plain or context-aware)
+// previously registered under the same status ID. It also maintains the
+// order in which validators were added.
+func (kc *keyChain[T]) SetValidatorContext(status Status, fn func(ctx context.Context, a T) (bool, error)) error {
+	if kc == nil {
+		return ErrNilReceiver{}
+	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	if kc.validators == nil {
+		kc.validators = validatorsMap[T]{}
+	}
+	if _, exists := kc.validators[status.ID]; !exists {
+		kc.order = append(kc.order, status.ID)
+	}
+	kc.validators.SetContext(status, fn)
+	delete(kc.refs, status.ID)
+	return nil
+}
+
+// SetParallelism sets how many validators ValidateContext may run
+// concurrently for the OR, AND and XOR conditions. n must be >= 1; n == 1
+// (the default) preserves the sequential, deterministic "last-label-wins"
+// semantics of Validate.
+func (kc *keyChain[T]) SetParallelism(n int) error {
+	if kc == nil {
+		return ErrNilReceiver{}
+	}
+	if n < 1 {
+		return ErrInvalidParallelism(n)
+	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.parallelism = n
+	return nil
+}
+
 // Validate processes the given data against all registered validators according
 // to the set bitwise condition (NOT, AND, OR, XOR). It returns the resulting
 // Status, a boolean indicating overall success, and a slice of any errors
@@ -215,19 +310,41 @@ func (kc *keyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGette
 	if kc == nil {
 		return nil, false, []error{ErrNilReceiver{}}
 	}
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
 	if kc.validators == nil {
 		return defaultStatus, false, nil
 	}
+	return validateSequential(kc.condition, kc.validators, kc.order, data, defaultStatus, nil)
+}
+
+// validateSequential implements the NOT/AND/OR/XOR evaluation shared by
+// keyChain.Validate, immutableKeyChain.Validate and
+// keyChainTranslated.Validate. It assumes validators and order are not
+// concurrently mutated for the duration of the call; callers are
+// responsible for any locking that guarantees this.
+//
+// onFail, if non-nil, is consulted with the id of the validator
+// responsible for a failing AND/OR/XOR result in place of defaultStatus
+// (e.g. to attach a translated Details message); a nil onFail, or one
+// passed a nil receiver, is equivalent to always returning defaultStatus
+// unchanged. NOT and the ambiguous XOR "more than one success" case have
+// no single attributable validator, so they always return defaultStatus
+// as-is, regardless of onFail.
+func validateSequential[T any](condition BitwiseID, validators validatorsMap[T], order []string, data T, defaultStatus StatusGetter, onFail func(id string) StatusGetter) (StatusGetter, bool, []error) {
+	if onFail == nil {
+		onFail = func(string) StatusGetter { return defaultStatus }
+	}
 	var (
 		ok   bool
 		lbl  Status
 		err  error
 		errs []error
 	)
-	switch kc.condition {
+	switch condition {
 	case NOT:
-		for _, id := range kc.order {
-			status, fn := kc.validators.Get(id)
+		for _, id := range order {
+			status, fn := validators.Get(id)
 			if fn == nil {
 				continue
 			}
@@ -239,8 +356,8 @@ func (kc *keyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGette
 		}
 		return &lbl, true, nil
 	case AND:
-		for _, id := range kc.order {
-			status, fn := kc.validators.Get(id)
+		for _, id := range order {
+			status, fn := validators.Get(id)
 			if fn == nil {
 				continue
 			}
@@ -249,14 +366,15 @@ func (kc *keyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGette
 				if err != nil {
 					errs = append(errs, err)
 				}
-				return defaultStatus, false, errs
+				return onFail(id), false, errs
 			}
 			lbl = status
 		}
 		return &lbl, ok, nil
 	case OR:
-		for _, id := range kc.order {
-			status, fn := kc.validators.Get(id)
+		var lastFailedID string
+		for _, id := range order {
+			status, fn := validators.Get(id)
 			if fn == nil {
 				continue
 			}
@@ -267,12 +385,16 @@ func (kc *keyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGette
 			if err != nil {
 				errs = append(errs, err)
 			}
+			lastFailedID = id
 		}
-		return defaultStatus, false, errs
+		return onFail(lastFailedID), false, errs
 	case XOR:
-		var trueCount uint
-		for _, id := range kc.order {
-			status, fn := kc.validators.Get(id)
+		var (
+			trueCount    uint
+			lastFailedID string
+		)
+		for _, id := range order {
+			status, fn := validators.Get(id)
 			if fn == nil {
 				continue
 			}
@@ -283,25 +405,36 @@ func (kc *keyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGette
 					return defaultStatus, false, nil
 				}
 				lbl = status
-			} else if err != nil {
-				errs = append(errs, err)
+			} else {
+				if err != nil {
+					errs = append(errs, err)
+				}
+				lastFailedID = id
 			}
 		}
 		if trueCount == 1 {
 			return &lbl, true, nil
 		}
-		return defaultStatus, false, errs
+		return onFail(lastFailedID), false, errs
 	}
 	return defaultStatus, false, nil
 }
 
 // Reset clears all validators, the validation order, and the bitwise
-// condition, restoring the keychain to its initial empty state.
-func (kc *keyChain[T]) Reset() {
+// condition, restoring the keychain to its initial empty state. It
+// returns a nil error unless kc is nil; the return value exists so Reset can be
+// part of the shared KeyChain interface alongside ImmutableKeyChain,
+// whose Reset always returns an error.
+func (kc *keyChain[T]) Reset() error {
 	if kc == nil {
-		return
+		return ErrNilReceiver{}
 	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
 	kc.condition = 0
 	kc.validators = nil
 	kc.order = nil
+	kc.parallelism = 1
+	kc.refs = nil
+	return nil
 }
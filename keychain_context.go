@@ -0,0 +1,251 @@
+package keycheck
+
+import (
+	"context"
+	"sync"
+)
+
+// resolvedValidator is a snapshot of one registered validator, captured
+// under lock so the rest of ValidateContext can run without holding it
+// for the (potentially I/O-bound) duration of validation.
+type resolvedValidator[T any] struct {
+	status Status
+	fn     func(ctx context.Context, a T) (bool, error)
+}
+
+// snapshotContextValidators copies the keychain's condition, parallelism
+// and resolved validators under a read lock, then releases it.
+func (kc *keyChain[T]) snapshotContextValidators() (condition BitwiseID, parallelism int, entries []resolvedValidator[T], empty bool) {
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
+	if kc.validators == nil {
+		return kc.condition, kc.parallelism, nil, true
+	}
+	entries = make([]resolvedValidator[T], 0, len(kc.order))
+	for _, id := range kc.order {
+		status, fn := kc.validators.GetContext(id)
+		if fn == nil {
+			continue
+		}
+		entries = append(entries, resolvedValidator[T]{status: status, fn: fn})
+	}
+	return kc.condition, kc.parallelism, entries, false
+}
+
+// ValidateContext is the context-aware, optionally concurrent counterpart
+// to Validate. Validators registered via SetValidatorContext receive ctx
+// directly; validators registered via SetValidator are adapted to ignore
+// it. If SetParallelism was never called, or was set to 1, validation is
+// sequential and deterministic, exactly like Validate. For n > 1, the OR,
+// AND and XOR conditions dispatch independent validators to a pool of at
+// most n workers: OR returns as soon as one succeeds, cancelling the
+// rest; AND returns as soon as one fails, cancelling the rest; XOR waits
+// for every validator to finish (its result depends on the total count of
+// successes) but still cancels outstanding work once the outcome is
+// already decided, e.g. after a second success is observed. The NOT
+// condition always runs sequentially, since it must inspect every
+// validator in order regardless of parallelism. The keychain's own lock
+// is only held long enough to snapshot its validators, so a slow or
+// cancelled validation never blocks concurrent SetValidator/DelValidator
+// calls.
+func (kc *keyChain[T]) ValidateContext(ctx context.Context, data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if kc == nil {
+		return nil, false, []error{ErrNilReceiver{}}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	condition, parallelism, entries, empty := kc.snapshotContextValidators()
+	if empty {
+		return defaultStatus, false, nil
+	}
+	if parallelism <= 1 {
+		return validateContextSequential(ctx, condition, entries, data, defaultStatus)
+	}
+	switch condition {
+	case AND:
+		return validateContextAND(ctx, parallelism, entries, data, defaultStatus)
+	case OR:
+		return validateContextOR(ctx, parallelism, entries, data, defaultStatus)
+	case XOR:
+		return validateContextXOR(ctx, parallelism, entries, data, defaultStatus)
+	default:
+		return validateContextSequential(ctx, condition, entries, data, defaultStatus)
+	}
+}
+
+// validateContextSequential mirrors Validate but calls the context-aware
+// form of each validator and aborts as soon as ctx is cancelled.
+func validateContextSequential[T any](ctx context.Context, condition BitwiseID, entries []resolvedValidator[T], data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	var (
+		ok   bool
+		lbl  Status
+		err  error
+		errs []error
+	)
+	switch condition {
+	case NOT:
+		for _, v := range entries {
+			if cErr := ctx.Err(); cErr != nil {
+				return defaultStatus, false, append(errs, cErr)
+			}
+			if ok, _ = v.fn(ctx, data); !ok {
+				lbl = v.status
+				continue
+			}
+			return defaultStatus, false, errs
+		}
+		return &lbl, true, nil
+	case AND:
+		for _, v := range entries {
+			if cErr := ctx.Err(); cErr != nil {
+				return defaultStatus, false, append(errs, cErr)
+			}
+			ok, err = v.fn(ctx, data)
+			if !ok {
+				if err != nil {
+					errs = append(errs, err)
+				}
+				return defaultStatus, false, errs
+			}
+			lbl = v.status
+		}
+		return &lbl, ok, nil
+	case OR:
+		for _, v := range entries {
+			if cErr := ctx.Err(); cErr != nil {
+				return defaultStatus, false, append(errs, cErr)
+			}
+			ok, err = v.fn(ctx, data)
+			if ok {
+				return &v.status, true, nil
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return defaultStatus, false, errs
+	case XOR:
+		var trueCount uint
+		for _, v := range entries {
+			if cErr := ctx.Err(); cErr != nil {
+				return defaultStatus, false, append(errs, cErr)
+			}
+			ok, err = v.fn(ctx, data)
+			if ok {
+				trueCount++
+				if trueCount > 1 {
+					return defaultStatus, false, nil
+				}
+				lbl = v.status
+			} else if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if trueCount == 1 {
+			return &lbl, true, nil
+		}
+		return defaultStatus, false, errs
+	}
+	return defaultStatus, false, nil
+}
+
+type contextValidateResult struct {
+	status Status
+	ok     bool
+	err    error
+}
+
+// dispatchContextValidators runs every entry against a pool of at most
+// parallelism workers, using cctx as the context passed to each. Dispatch
+// itself happens in its own goroutine so the returned channel is handed
+// back to the caller immediately, rather than after every worker has been
+// scheduled; this lets the caller cancel cctx (e.g. on short-circuit)
+// while dispatch is still gating later workers behind the semaphore,
+// instead of only once there is nothing left to cancel. Results are
+// delivered on the returned channel, which is buffered to hold one result
+// per entry so the caller may stop consuming it early without leaking
+// goroutines.
+func dispatchContextValidators[T any](cctx context.Context, parallelism int, entries []resolvedValidator[T], data T) <-chan contextValidateResult {
+	results := make(chan contextValidateResult, len(entries))
+	go func() {
+		sem := make(chan struct{}, parallelism)
+		var wg sync.WaitGroup
+		for _, v := range entries {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(v resolvedValidator[T]) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				ok, err := v.fn(cctx, data)
+				results <- contextValidateResult{status: v.status, ok: ok, err: err}
+			}(v)
+		}
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+func validateContextOR[T any](ctx context.Context, parallelism int, entries []resolvedValidator[T], data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errs []error
+	for r := range dispatchContextValidators(cctx, parallelism, entries, data) {
+		if r.ok {
+			cancel()
+			return &r.status, true, errs
+		}
+		if r.err != nil {
+			errs = append(errs, r.err)
+		}
+	}
+	return defaultStatus, false, errs
+}
+
+func validateContextAND[T any](ctx context.Context, parallelism int, entries []resolvedValidator[T], data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errs []error
+	for r := range dispatchContextValidators(cctx, parallelism, entries, data) {
+		if !r.ok {
+			cancel()
+			if r.err != nil {
+				errs = append(errs, r.err)
+			}
+			return defaultStatus, false, errs
+		}
+	}
+	if len(entries) == 0 {
+		return defaultStatus, false, errs
+	}
+	return &entries[len(entries)-1].status, true, nil
+}
+
+func validateContextXOR[T any](ctx context.Context, parallelism int, entries []resolvedValidator[T], data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		trueCount uint
+		winner    Status
+		errs      []error
+	)
+	for r := range dispatchContextValidators(cctx, parallelism, entries, data) {
+		if r.ok {
+			trueCount++
+			winner = r.status
+			if trueCount > 1 {
+				cancel()
+			}
+		} else if r.err != nil {
+			errs = append(errs, r.err)
+		}
+	}
+	if trueCount == 1 {
+		return &winner, true, nil
+	}
+	return defaultStatus, false, errs
+}
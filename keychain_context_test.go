@@ -0,0 +1,142 @@
+package keycheck_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/colduction/keycheck-go"
+)
+
+func slowCtx(delay time.Duration, ok bool) func(context.Context, string) (bool, error) {
+	return func(ctx context.Context, _ string) (bool, error) {
+		select {
+		case <-time.After(delay):
+			if ok {
+				return true, nil
+			}
+			return false, errors.New("slowCtx failed")
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+}
+
+func TestKeyChain_SetParallelism(t *testing.T) {
+	kc, _ := keycheck.NewKeyChain[string](keycheck.OR)
+	if err := kc.SetParallelism(0); err == nil {
+		t.Error("SetParallelism(0) should return an error")
+	}
+	if err := kc.SetParallelism(4); err != nil {
+		t.Errorf("SetParallelism(4) returned an unexpected error: %v", err)
+	}
+}
+
+func TestKeyChain_ValidateContext(t *testing.T) {
+	t.Run("Sequential default matches Validate", func(t *testing.T) {
+		kc, _ := keycheck.NewKeyChain[string](keycheck.OR)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "a"}, slowCtx(time.Millisecond, false))
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "b"}, slowCtx(time.Millisecond, true))
+
+		label, ok, _ := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		if !ok || label.GetID() != "b" {
+			t.Errorf("expected label 'b' and success, got label=%v ok=%v", label, ok)
+		}
+	})
+
+	t.Run("OR cancels remaining validators once one succeeds", func(t *testing.T) {
+		var cancelled atomic.Bool
+		kc, _ := keycheck.NewKeyChain[string](keycheck.OR)
+		_ = kc.SetParallelism(4)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "fast"}, slowCtx(time.Millisecond, true))
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "slow"}, func(ctx context.Context, s string) (bool, error) {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return true, nil
+			case <-ctx.Done():
+				cancelled.Store(true)
+				return false, ctx.Err()
+			}
+		})
+
+		_, ok, _ := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		if !ok {
+			t.Error("expected OR validation to succeed")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if !cancelled.Load() {
+			t.Error("expected the slower validator to observe cancellation")
+		}
+	})
+
+	t.Run("OR short-circuits even when parallelism is smaller than the validator count", func(t *testing.T) {
+		kc, _ := keycheck.NewKeyChain[string](keycheck.OR)
+		_ = kc.SetParallelism(2)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "fast"}, slowCtx(time.Millisecond, true))
+		for i := 0; i < 8; i++ {
+			_ = kc.SetValidatorContext(keycheck.Status{ID: fmt.Sprintf("slow%d", i)}, slowCtx(100*time.Millisecond, true))
+		}
+
+		start := time.Now()
+		_, ok, _ := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		elapsed := time.Since(start)
+		if !ok {
+			t.Error("expected OR validation to succeed")
+		}
+		if elapsed > 50*time.Millisecond {
+			t.Errorf("expected OR to short-circuit near-instantly once 'fast' succeeds, took %v", elapsed)
+		}
+	})
+
+	t.Run("AND fails fast when one validator fails", func(t *testing.T) {
+		kc, _ := keycheck.NewKeyChain[string](keycheck.AND)
+		_ = kc.SetParallelism(4)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "a"}, slowCtx(time.Millisecond, false))
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "b"}, slowCtx(5*time.Millisecond, true))
+
+		_, ok, errs := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		if ok {
+			t.Error("expected AND validation to fail")
+		}
+		if len(errs) == 0 {
+			t.Error("expected at least one error")
+		}
+	})
+
+	t.Run("AND fails fast even when parallelism is smaller than the validator count", func(t *testing.T) {
+		kc, _ := keycheck.NewKeyChain[string](keycheck.AND)
+		_ = kc.SetParallelism(2)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "fast"}, slowCtx(time.Millisecond, false))
+		for i := 0; i < 8; i++ {
+			_ = kc.SetValidatorContext(keycheck.Status{ID: fmt.Sprintf("slow%d", i)}, slowCtx(100*time.Millisecond, true))
+		}
+
+		start := time.Now()
+		_, ok, errs := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		elapsed := time.Since(start)
+		if ok {
+			t.Error("expected AND validation to fail")
+		}
+		if len(errs) == 0 {
+			t.Error("expected at least one error")
+		}
+		if elapsed > 50*time.Millisecond {
+			t.Errorf("expected AND to fail near-instantly once 'fast' fails, took %v", elapsed)
+		}
+	})
+
+	t.Run("XOR waits for all and detects multiple successes", func(t *testing.T) {
+		kc, _ := keycheck.NewKeyChain[string](keycheck.XOR)
+		_ = kc.SetParallelism(4)
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "a"}, slowCtx(time.Millisecond, true))
+		_ = kc.SetValidatorContext(keycheck.Status{ID: "b"}, slowCtx(time.Millisecond, true))
+
+		_, ok, _ := kc.ValidateContext(context.Background(), "any", keycheck.FAIL)
+		if ok {
+			t.Error("expected XOR validation to fail for two successes")
+		}
+	})
+}
@@ -0,0 +1,344 @@
+package keycheck
+
+import "context"
+
+type (
+	ErrNilExpr            struct{}
+	ErrUnsupportedForExpr struct{ Op string }
+)
+
+func (err ErrNilExpr) Error() string {
+	return "keycheck: expression is nil"
+}
+
+func (err ErrUnsupportedForExpr) Error() string {
+	return "keycheck: " + err.Op + " is not supported on an expression-based keychain"
+}
+
+// Expr is a node in a boolean expression tree that composes labeled
+// validators registered on an expression-based KeyChain via logical
+// AND, OR, NOT and XOR. Trees are built with And, Or, Not, Xor and Ref
+// and passed to NewKeyChainExpr.
+type Expr interface {
+	isExpr()
+}
+
+type refExpr struct {
+	label string
+}
+
+func (refExpr) isExpr() {}
+
+// Ref references a validator previously registered via SetValidator by
+// its status ID, so it can be used as a leaf in an expression tree.
+func Ref(label string) Expr {
+	return refExpr{label: label}
+}
+
+type andExpr struct {
+	children []Expr
+}
+
+func (andExpr) isExpr() {}
+
+// And succeeds when every child expression succeeds, evaluating children
+// left to right and short-circuiting on the first failure.
+func And(children ...Expr) Expr {
+	return andExpr{children: children}
+}
+
+type orExpr struct {
+	children []Expr
+}
+
+func (orExpr) isExpr() {}
+
+// Or succeeds when at least one child expression succeeds, evaluating
+// children left to right and short-circuiting on the first success.
+func Or(children ...Expr) Expr {
+	return orExpr{children: children}
+}
+
+type notExpr struct {
+	child Expr
+}
+
+func (notExpr) isExpr() {}
+
+// Not succeeds when its child expression fails.
+func Not(child Expr) Expr {
+	return notExpr{child: child}
+}
+
+type xorExpr struct {
+	children []Expr
+}
+
+func (xorExpr) isExpr() {}
+
+// Xor succeeds when exactly one child expression succeeds. Unlike And
+// and Or it cannot short-circuit, since every child must be evaluated to
+// know whether more than one has succeeded.
+func Xor(children ...Expr) Expr {
+	return xorExpr{children: children}
+}
+
+// exprEvalResult caches the outcome of evaluating a single referenced
+// validator for the lifetime of one Validate call.
+type exprEvalResult struct {
+	ok  bool
+	err error
+}
+
+// evalExpr walks expr against data, memoizing each referenced validator
+// in memo so its function runs at most once per Validate call. Whether
+// an error is appended to errs is decided per reference rather than per
+// call to the underlying function: a cached result's error is reported
+// again at every reference site that reaches it with a "real" errs
+// (i.e. every reference outside a Not, which instead passes its own
+// call a throwaway slice - see the notExpr case). This is what lets the
+// same Ref, reached once through a Not and once directly, still report
+// its error at the direct reference even though the function itself
+// only actually ran the first time. It returns whether the node
+// succeeded and, if so, the label of the leaf responsible for that
+// outcome.
+func evalExpr[T any](expr Expr, data T, validators validatorsMap[T], memo map[string]exprEvalResult, errs *[]error) (bool, string) {
+	switch e := expr.(type) {
+	case refExpr:
+		res, cached := memo[e.label]
+		if !cached {
+			_, fn := validators.Get(e.label)
+			if fn == nil {
+				res = exprEvalResult{ok: false, err: ErrNoValidatorExist{}}
+			} else {
+				ok, err := fn(data)
+				res = exprEvalResult{ok: ok, err: err}
+			}
+			memo[e.label] = res
+		}
+		if res.err != nil {
+			*errs = append(*errs, res.err)
+		}
+		return res.ok, e.label
+	case andExpr:
+		var label string
+		for _, child := range e.children {
+			ok, l := evalExpr(child, data, validators, memo, errs)
+			if !ok {
+				return false, ""
+			}
+			if l != "" {
+				label = l
+			}
+		}
+		return true, label
+	case orExpr:
+		for _, child := range e.children {
+			if ok, l := evalExpr(child, data, validators, memo, errs); ok {
+				return true, l
+			}
+		}
+		return false, ""
+	case notExpr:
+		// Mirrors the NOT condition in validateSequential, which also
+		// discards the child's error: Not inverts a failure into success,
+		// and that failure's error is expected, not exceptional.
+		var childErrs []error
+		ok, _ := evalExpr(e.child, data, validators, memo, &childErrs)
+		return !ok, ""
+	case xorExpr:
+		var (
+			trueCount int
+			label     string
+		)
+		for _, child := range e.children {
+			if ok, l := evalExpr(child, data, validators, memo, errs); ok {
+				trueCount++
+				if l != "" {
+					label = l
+				}
+			}
+		}
+		return trueCount == 1, label
+	default:
+		return false, ""
+	}
+}
+
+// evalExprContext is the context-aware counterpart to evalExpr. It
+// checks ctx for cancellation before invoking each leaf validator and
+// memoizes results the same way, so a cancelled context short-circuits
+// the remainder of the tree with an error instead of a validator result.
+func evalExprContext[T any](ctx context.Context, expr Expr, data T, validators validatorsMap[T], memo map[string]exprEvalResult, errs *[]error) (bool, string) {
+	switch e := expr.(type) {
+	case refExpr:
+		res, cached := memo[e.label]
+		if !cached {
+			if cErr := ctx.Err(); cErr != nil {
+				res = exprEvalResult{ok: false, err: cErr}
+			} else {
+				_, fn := validators.GetContext(e.label)
+				if fn == nil {
+					res = exprEvalResult{ok: false, err: ErrNoValidatorExist{}}
+				} else {
+					ok, err := fn(ctx, data)
+					res = exprEvalResult{ok: ok, err: err}
+				}
+			}
+			memo[e.label] = res
+		}
+		if res.err != nil {
+			*errs = append(*errs, res.err)
+		}
+		return res.ok, e.label
+	case andExpr:
+		var label string
+		for _, child := range e.children {
+			ok, l := evalExprContext(ctx, child, data, validators, memo, errs)
+			if !ok {
+				return false, ""
+			}
+			if l != "" {
+				label = l
+			}
+		}
+		return true, label
+	case orExpr:
+		for _, child := range e.children {
+			if ok, l := evalExprContext(ctx, child, data, validators, memo, errs); ok {
+				return true, l
+			}
+		}
+		return false, ""
+	case notExpr:
+		// Mirrors the NOT condition in validateSequential, which also
+		// discards the child's error: Not inverts a failure into success,
+		// and that failure's error is expected, not exceptional.
+		var childErrs []error
+		ok, _ := evalExprContext(ctx, e.child, data, validators, memo, &childErrs)
+		return !ok, ""
+	case xorExpr:
+		var (
+			trueCount int
+			label     string
+		)
+		for _, child := range e.children {
+			if ok, l := evalExprContext(ctx, child, data, validators, memo, errs); ok {
+				trueCount++
+				if l != "" {
+					label = l
+				}
+			}
+		}
+		return trueCount == 1, label
+	default:
+		return false, ""
+	}
+}
+
+// keyChainExpr is a KeyChain whose validation logic is driven by an
+// arbitrary boolean Expr tree over labeled validators, rather than a
+// single BitwiseID applied uniformly to all of them.
+type keyChainExpr[T any] struct {
+	*keyChain[T]
+	root Expr
+}
+
+// NewKeyChainExpr creates a KeyChain[T] whose Validate behaviour is
+// defined by expr instead of a single BitwiseID condition. Each leaf in
+// expr is a Ref to a label registered via SetValidator; the same chain
+// can then express trees like And(Or(Ref("a"), Ref("b")), Not(Ref("c"))) that the
+// four uniform modes from NewKeyChain cannot represent on their own.
+func NewKeyChainExpr[T any](expr Expr) (KeyChain[T], error) {
+	if expr == nil {
+		return nil, ErrNilExpr{}
+	}
+	return &keyChainExpr[T]{
+		keyChain: &keyChain[T]{
+			validators: validatorsMap[T]{},
+			order:      []string{},
+		},
+		root: expr,
+	}, nil
+}
+
+// Marshal is not supported for an expression-based keychain: the
+// declarative document schema LoadKeyChain/Marshal share has no
+// representation for an arbitrary Expr tree. It always returns an error.
+func (kc *keyChainExpr[T]) Marshal(marshal func(v any) ([]byte, error)) ([]byte, error) {
+	return nil, ErrUnsupportedForExpr{Op: "Marshal"}
+}
+
+// SetCondition is not meaningful for an expression-based keychain, since
+// its logic is defined by the Expr tree passed to NewKeyChainExpr. It
+// always returns an error.
+func (kc *keyChainExpr[T]) SetCondition(condition BitwiseID) error {
+	return ErrUnsupportedForExpr{Op: "SetCondition"}
+}
+
+// Validate evaluates the keychain's Expr tree against data. Each
+// referenced validator runs at most once, AND/OR short-circuit the way
+// Go's own operators do, and on success the returned StatusGetter is the
+// Status of the leaf validator responsible for that outcome, or
+// defaultStatus when no leaf is (e.g. the success came from a Not); on
+// failure it is defaultStatus. The aggregated error slice holds the error
+// of every reference reached outside a Not; errors seen only beneath a
+// Not are discarded.
+func (kc *keyChainExpr[T]) Validate(data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if kc == nil || kc.keyChain == nil {
+		return nil, false, []error{ErrNilReceiver{}}
+	}
+	if kc.root == nil {
+		return nil, false, []error{ErrNilExpr{}}
+	}
+	kc.keyChain.mu.RLock()
+	defer kc.keyChain.mu.RUnlock()
+	if kc.keyChain.validators == nil {
+		return defaultStatus, false, nil
+	}
+	memo := make(map[string]exprEvalResult, len(kc.keyChain.validators))
+	var errs []error
+	ok, label := evalExpr(kc.root, data, kc.keyChain.validators, memo, &errs)
+	if !ok {
+		return defaultStatus, false, errs
+	}
+	if label == "" {
+		return defaultStatus, true, errs
+	}
+	status, _ := kc.keyChain.validators.Get(label)
+	return &status, true, errs
+}
+
+// ValidateContext is the context-aware counterpart to Validate. It walks
+// the same Expr tree, preferring validators registered via
+// SetValidatorContext, and aborts the remainder of the tree once ctx is
+// cancelled. Parallelism set via SetParallelism does not apply here,
+// since the tree's own AND/OR short-circuiting already determines what
+// needs to run; each leaf still executes at most once per call.
+func (kc *keyChainExpr[T]) ValidateContext(ctx context.Context, data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if kc == nil || kc.keyChain == nil {
+		return nil, false, []error{ErrNilReceiver{}}
+	}
+	if kc.root == nil {
+		return nil, false, []error{ErrNilExpr{}}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	kc.keyChain.mu.RLock()
+	defer kc.keyChain.mu.RUnlock()
+	if kc.keyChain.validators == nil {
+		return defaultStatus, false, nil
+	}
+	memo := make(map[string]exprEvalResult, len(kc.keyChain.validators))
+	var errs []error
+	ok, label := evalExprContext(ctx, kc.root, data, kc.keyChain.validators, memo, &errs)
+	if !ok {
+		return defaultStatus, false, errs
+	}
+	if label == "" {
+		return defaultStatus, true, errs
+	}
+	status, _ := kc.keyChain.validators.Get(label)
+	return &status, true, errs
+}
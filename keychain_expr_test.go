@@ -0,0 +1,123 @@
+package keycheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/colduction/keycheck-go"
+)
+
+func TestNewKeyChainExpr(t *testing.T) {
+	t.Run("Nil Expr", func(t *testing.T) {
+		if _, err := keycheck.NewKeyChainExpr[string](nil); err == nil {
+			t.Error("NewKeyChainExpr with a nil Expr should return an error")
+		}
+	})
+
+	t.Run("SetCondition Unsupported", func(t *testing.T) {
+		kc, err := keycheck.NewKeyChainExpr[string](keycheck.Ref("a"))
+		if err != nil {
+			t.Fatalf("NewKeyChainExpr returned an error: %v", err)
+		}
+		if err := kc.SetCondition(keycheck.AND); err == nil {
+			t.Error("SetCondition should return an error on an expression-based keychain")
+		}
+	})
+}
+
+func TestKeyChainExpr_Validate(t *testing.T) {
+	newExprChain := func(t *testing.T, expr keycheck.Expr) keycheck.KeyChain[string] {
+		t.Helper()
+		kc, err := keycheck.NewKeyChainExpr[string](expr)
+		if err != nil {
+			t.Fatalf("NewKeyChainExpr returned an error: %v", err)
+		}
+		return kc
+	}
+
+	t.Run("And(Or(a,b), Not(c))", func(t *testing.T) {
+		calls := map[string]int{}
+		track := func(label string, fn func(string) (bool, error)) func(string) (bool, error) {
+			return func(s string) (bool, error) {
+				calls[label]++
+				return fn(s)
+			}
+		}
+
+		kc := newExprChain(t, keycheck.And(keycheck.Or(keycheck.Ref("a"), keycheck.Ref("b")), keycheck.Not(keycheck.Ref("c"))))
+		_ = kc.SetValidator(keycheck.Status{ID: "a"}, track("a", alwaysTrue))
+		_ = kc.SetValidator(keycheck.Status{ID: "b"}, track("b", alwaysTrue))
+		_ = kc.SetValidator(keycheck.Status{ID: "c"}, track("c", alwaysFalse))
+
+		label, ok, errs := kc.Validate("any", keycheck.FAIL)
+		if !ok {
+			t.Fatal("expected validation to succeed")
+		}
+		if label.GetID() != "a" {
+			t.Errorf("expected triggering label 'a' from the short-circuited Or, got '%s'", label.GetID())
+		}
+		if calls["a"] != 1 || calls["b"] != 0 {
+			t.Errorf("Or should short-circuit after 'a' succeeds, got calls=%v", calls)
+		}
+		if calls["c"] != 1 {
+			t.Errorf("expected 'c' to be evaluated exactly once, got %d", calls["c"])
+		}
+		if errs != nil {
+			t.Errorf("expected no errors, got %v", errs)
+		}
+	})
+
+	t.Run("Ref memoized across repeated use", func(t *testing.T) {
+		calls := 0
+		kc := newExprChain(t, keycheck.Xor(keycheck.Ref("shared"), keycheck.Not(keycheck.Ref("shared"))))
+		_ = kc.SetValidator(keycheck.Status{ID: "shared"}, func(s string) (bool, error) {
+			calls++
+			return true, nil
+		})
+
+		_, ok, _ := kc.Validate("any", keycheck.FAIL)
+		if !ok {
+			t.Error("exactly one of Ref(shared) and Not(Ref(shared)) should succeed")
+		}
+		if calls != 1 {
+			t.Errorf("expected 'shared' to be evaluated once across the whole tree, got %d", calls)
+		}
+	})
+
+	t.Run("direct reference still reports the error after a Not sees it first", func(t *testing.T) {
+		calls := 0
+		kc := newExprChain(t, keycheck.Xor(keycheck.Not(keycheck.Ref("shared")), keycheck.Ref("shared")))
+		_ = kc.SetValidator(keycheck.Status{ID: "shared"}, func(s string) (bool, error) {
+			calls++
+			return false, errors.New("shared failed")
+		})
+
+		_, ok, errs := kc.Validate("any", keycheck.FAIL)
+		if !ok {
+			t.Error("expected Not(Ref(shared)) to be the sole success")
+		}
+		if calls != 1 {
+			t.Errorf("expected 'shared' to be evaluated once across the whole tree, got %d", calls)
+		}
+		if len(errs) != 1 {
+			t.Errorf("expected the direct Ref(shared) reference to still report its error, got %v", errs)
+		}
+	})
+
+	t.Run("Failure returns default status", func(t *testing.T) {
+		kc := newExprChain(t, keycheck.And(keycheck.Ref("a"), keycheck.Ref("b")))
+		_ = kc.SetValidator(keycheck.Status{ID: "a"}, alwaysTrue)
+		_ = kc.SetValidator(keycheck.Status{ID: "b"}, alwaysFalse)
+
+		label, ok, errs := kc.Validate("any", keycheck.FAIL)
+		if ok {
+			t.Error("expected validation to fail")
+		}
+		if label != keycheck.FAIL {
+			t.Errorf("expected default label 'FAIL', got '%s'", label)
+		}
+		if len(errs) != 1 {
+			t.Errorf("expected 1 error, got %d", len(errs))
+		}
+	})
+}
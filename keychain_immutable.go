@@ -0,0 +1,181 @@
+package keycheck
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+// ErrImmutableKeyChain is returned by every mutating method of an
+// ImmutableKeyChain, since its validators and condition are fixed for
+// its entire lifetime once built.
+type ErrImmutableKeyChain struct{ Op string }
+
+func (err ErrImmutableKeyChain) Error() string {
+	return "keycheck: " + err.Op + " is not supported on an immutable keychain"
+}
+
+// KeyChainBuilder assembles the validators and condition for an
+// ImmutableKeyChain[T] before any validation traffic begins. Once Build
+// returns, the resulting keychain never changes, so its Validate path
+// needs no locking and is safe for many goroutines to call concurrently.
+type KeyChainBuilder[T any] struct {
+	condition  BitwiseID
+	validators validatorsMap[T]
+	order      []string
+	err        error
+}
+
+// NewKeyChainBuilder starts building an ImmutableKeyChain[T] governed by
+// condition (e.g. AND, OR). Chain With calls to register validators, then
+// call Build.
+func NewKeyChainBuilder[T any](condition BitwiseID) *KeyChainBuilder[T] {
+	b := &KeyChainBuilder[T]{
+		condition:  condition,
+		validators: validatorsMap[T]{},
+		order:      []string{},
+	}
+	if !condition.IsValid() {
+		b.err = ErrInvalidBitwiseID(condition)
+	}
+	return b
+}
+
+// With registers a validator function for status and returns the builder,
+// so calls can be chained:
+// NewKeyChainBuilder[T](cond).With(s1, fn1).With(s2, fn2).Build().
+func (b *KeyChainBuilder[T]) With(status Status, fn func(a T) (bool, error)) *KeyChainBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+	if _, exists := b.validators[status.ID]; !exists {
+		b.order = append(b.order, status.ID)
+	}
+	b.validators.Set(status, fn)
+	return b
+}
+
+// Build finalizes the builder into a KeyChain[T] backed by an
+// ImmutableKeyChain. It returns an error if the builder's condition was
+// invalid.
+func (b *KeyChainBuilder[T]) Build() (KeyChain[T], error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &immutableKeyChain[T]{
+		condition:  b.condition,
+		validators: maps.Clone(b.validators),
+		order:      slices.Clone(b.order),
+	}, nil
+}
+
+// immutableKeyChain is a KeyChain[T] whose validators and condition are
+// fixed at construction time via KeyChainBuilder. Because nothing about
+// it ever changes after Build, Validate and ValidateContext read its
+// fields without any locking.
+type immutableKeyChain[T any] struct {
+	condition  BitwiseID
+	validators validatorsMap[T]
+	order      []string
+}
+
+// DelValidator always fails: an ImmutableKeyChain's validators cannot be
+// removed after Build.
+func (kc *immutableKeyChain[T]) DelValidator(label string) error {
+	return ErrImmutableKeyChain{Op: "DelValidator"}
+}
+
+// GetValidator retrieves a validator function by its label, exactly like
+// keyChain.GetValidator.
+func (kc *immutableKeyChain[T]) GetValidator(id string) (Status, func(a T) (bool, error), error) {
+	if kc.validators == nil {
+		return Status{}, nil, ErrNoValidatorExist{}
+	}
+	status, fn := kc.validators.Get(id)
+	return status, fn, nil
+}
+
+// Reset always fails: an ImmutableKeyChain cannot be emptied out and
+// reused after Build; build a new one instead.
+func (kc *immutableKeyChain[T]) Reset() error {
+	return ErrImmutableKeyChain{Op: "Reset"}
+}
+
+// Marshal encodes the keychain's condition and validators into the same
+// document shape as keyChain.Marshal. Since an immutable keychain is
+// never built from LoadKeyChain, every validator's ref is empty.
+func (kc *immutableKeyChain[T]) Marshal(marshal func(v any) ([]byte, error)) ([]byte, error) {
+	if !kc.condition.IsValid() {
+		return nil, ErrInvalidBitwiseID(kc.condition)
+	}
+	doc := keyChainDocument{
+		Condition:  conditionLabels[kc.condition],
+		Validators: make([]validatorDocument, 0, len(kc.order)),
+	}
+	for _, id := range kc.order {
+		status, fn := kc.validators.Get(id)
+		if fn == nil {
+			continue
+		}
+		doc.Validators = append(doc.Validators, validatorDocument{
+			ID:      status.ID,
+			Details: status.Details,
+		})
+	}
+	return marshal(doc)
+}
+
+// SetCondition always fails: an ImmutableKeyChain's condition cannot be
+// changed after Build.
+func (kc *immutableKeyChain[T]) SetCondition(condition BitwiseID) error {
+	return ErrImmutableKeyChain{Op: "SetCondition"}
+}
+
+// SetParallelism always fails: Validate and ValidateContext are already
+// lock-free on an ImmutableKeyChain, so there is no writer contention for
+// parallelism to trade off against.
+func (kc *immutableKeyChain[T]) SetParallelism(n int) error {
+	return ErrImmutableKeyChain{Op: "SetParallelism"}
+}
+
+// SetValidator always fails: register validators through
+// KeyChainBuilder.With before Build instead.
+func (kc *immutableKeyChain[T]) SetValidator(status Status, fn func(a T) (bool, error)) error {
+	return ErrImmutableKeyChain{Op: "SetValidator"}
+}
+
+// SetValidatorContext always fails: an ImmutableKeyChain only supports
+// the plain validators registered through KeyChainBuilder.With.
+func (kc *immutableKeyChain[T]) SetValidatorContext(status Status, fn func(ctx context.Context, a T) (bool, error)) error {
+	return ErrImmutableKeyChain{Op: "SetValidatorContext"}
+}
+
+// Validate runs without taking any lock, since an ImmutableKeyChain's
+// validators and condition never change after Build.
+func (kc *immutableKeyChain[T]) Validate(data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if kc.validators == nil {
+		return defaultStatus, false, nil
+	}
+	return validateSequential(kc.condition, kc.validators, kc.order, data, defaultStatus, nil)
+}
+
+// ValidateContext adapts every validator to ignore ctx, aside from
+// checking it for cancellation between validators, and otherwise
+// evaluates sequentially and lock-free, the same as Validate.
+func (kc *immutableKeyChain[T]) ValidateContext(ctx context.Context, data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if kc.validators == nil {
+		return defaultStatus, false, nil
+	}
+	entries := make([]resolvedValidator[T], 0, len(kc.order))
+	for _, id := range kc.order {
+		status, fn := kc.validators.GetContext(id)
+		if fn == nil {
+			continue
+		}
+		entries = append(entries, resolvedValidator[T]{status: status, fn: fn})
+	}
+	return validateContextSequential(ctx, kc.condition, entries, data, defaultStatus)
+}
@@ -0,0 +1,92 @@
+package keycheck_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/colduction/keycheck-go"
+)
+
+func TestKeyChainBuilder(t *testing.T) {
+	t.Run("Invalid Condition", func(t *testing.T) {
+		if _, err := keycheck.NewKeyChainBuilder[string](keycheck.BitwiseID(255)).Build(); err == nil {
+			t.Error("Build with an invalid condition should return an error")
+		}
+	})
+
+	t.Run("Mutators Return Errors", func(t *testing.T) {
+		kc, err := keycheck.NewKeyChainBuilder[string](keycheck.OR).
+			With(keycheck.Status{ID: "a"}, alwaysFalse).
+			With(keycheck.Status{ID: "b"}, alwaysTrue).
+			Build()
+		if err != nil {
+			t.Fatalf("Build returned an error: %v", err)
+		}
+
+		if err := kc.SetValidator(keycheck.Status{ID: "c"}, alwaysTrue); err == nil {
+			t.Error("SetValidator should return an error on an ImmutableKeyChain")
+		}
+		if err := kc.DelValidator("a"); err == nil {
+			t.Error("DelValidator should return an error on an ImmutableKeyChain")
+		}
+		if err := kc.SetCondition(keycheck.AND); err == nil {
+			t.Error("SetCondition should return an error on an ImmutableKeyChain")
+		}
+		if err := kc.Reset(); err == nil {
+			t.Error("Reset should return an error on an ImmutableKeyChain")
+		}
+
+		label, ok, _ := kc.Validate("any", keycheck.FAIL)
+		if !ok || label.GetID() != "b" {
+			t.Errorf("expected label 'b' and success, got label=%v ok=%v", label, ok)
+		}
+	})
+}
+
+func alwaysTrueInt(i int) (bool, error) {
+	return true, nil
+}
+
+func TestKeyChain_ConcurrentReadWrite(t *testing.T) {
+	kc, _ := keycheck.NewKeyChain[int](keycheck.OR)
+	for i := range 4 {
+		_ = kc.SetValidator(keycheck.Status{ID: "v" + strconv.Itoa(i)}, alwaysTrueInt)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		stop = make(chan struct{})
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			id := "v" + strconv.Itoa(i%4)
+			_ = kc.SetValidator(keycheck.Status{ID: id}, alwaysTrueInt)
+			_ = kc.DelValidator(id)
+			_ = kc.SetValidator(keycheck.Status{ID: id}, alwaysTrueInt)
+		}
+	}()
+
+	var readers sync.WaitGroup
+	for range 8 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := 0; i < 500; i++ {
+				kc.Validate(i, keycheck.FAIL)
+			}
+		}()
+	}
+
+	readers.Wait()
+	close(stop)
+	wg.Wait()
+}
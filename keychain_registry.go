@@ -0,0 +1,146 @@
+package keycheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type (
+	ErrValidatorRefNotFound     struct{ Ref string }
+	ErrValidatorRefTypeMismatch struct{ Ref string }
+	ErrInvalidKeyChainDocument  struct{ Reason string }
+)
+
+func (err ErrValidatorRefNotFound) Error() string {
+	return fmt.Sprintf("keycheck: no validator registered under ref %q", err.Ref)
+}
+
+func (err ErrValidatorRefTypeMismatch) Error() string {
+	return fmt.Sprintf("keycheck: validator registered under ref %q does not match the requested type", err.Ref)
+}
+
+func (err ErrInvalidKeyChainDocument) Error() string {
+	return "keycheck: invalid keychain document: " + err.Reason
+}
+
+// validatorRegistry holds every validator registered via RegisterValidator,
+// keyed by name, so LoadKeyChain can resolve a document's "ref" fields at
+// runtime without the caller wiring up functions by hand.
+var validatorRegistry sync.Map // map[string]any, value is func(T) (bool, error) for some T
+
+// RegisterValidator registers fn under name so it can be referenced by a
+// declarative keychain document loaded with LoadKeyChain. Registering
+// under a name already in use replaces the previous entry.
+func RegisterValidator[T any](name string, fn func(a T) (bool, error)) {
+	validatorRegistry.Store(name, fn)
+}
+
+func lookupValidator[T any](name string) (func(a T) (bool, error), error) {
+	v, ok := validatorRegistry.Load(name)
+	if !ok {
+		return nil, ErrValidatorRefNotFound{Ref: name}
+	}
+	fn, ok := v.(func(a T) (bool, error))
+	if !ok {
+		return nil, ErrValidatorRefTypeMismatch{Ref: name}
+	}
+	return fn, nil
+}
+
+type validatorDocument struct {
+	ID      string `json:"id"`
+	Ref     string `json:"ref"`
+	Details string `json:"details,omitempty"`
+}
+
+type keyChainDocument struct {
+	Condition  string              `json:"condition"`
+	Validators []validatorDocument `json:"validators"`
+}
+
+var conditionNames = map[string]BitwiseID{
+	"NOT": NOT,
+	"AND": AND,
+	"OR":  OR,
+	"XOR": XOR,
+}
+
+var conditionLabels = [...]string{"NOT", "AND", "OR", "XOR"}
+
+// normalizeForJSON recursively replaces every map[interface{}]interface{}
+// produced by gopkg.in/yaml.v2-style Unmarshal with a map[string]interface{},
+// stringifying its keys with fmt.Sprint. encoding/json can only marshal
+// string-keyed maps, so without this LoadKeyChain's json.Marshal round-trip
+// fails on every YAML document decoded through yaml.v2.
+func normalizeForJSON(v any) any {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = normalizeForJSON(val)
+		}
+		return m
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = normalizeForJSON(val)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeForJSON(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// LoadKeyChain builds a KeyChain[T] from a declarative document such as
+// {"condition":"AND","validators":[{"id":"nonempty","ref":"strings.NonEmpty"}]}.
+// unmarshal decodes data into an intermediate value first (pass
+// json.Unmarshal for JSON, or a YAML library's Unmarshal for YAML); the
+// result is then normalized, re-encoded to JSON and decoded against the
+// package's json-tagged document schema, so both formats go through a
+// single code path. Each validator's ref is resolved against the
+// registry populated by RegisterValidator[T]; an unresolved or
+// mismatched ref fails the whole load.
+func LoadKeyChain[T any](data []byte, unmarshal func(data []byte, v any) error) (KeyChain[T], error) {
+	var raw any
+	if err := unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	normalized, err := json.Marshal(normalizeForJSON(raw))
+	if err != nil {
+		return nil, err
+	}
+	var doc keyChainDocument
+	if err := json.Unmarshal(normalized, &doc); err != nil {
+		return nil, err
+	}
+	condition, ok := conditionNames[doc.Condition]
+	if !ok {
+		return nil, ErrInvalidKeyChainDocument{Reason: fmt.Sprintf("unknown condition %q", doc.Condition)}
+	}
+	kc := &keyChain[T]{
+		validators:  validatorsMap[T]{},
+		condition:   condition,
+		order:       []string{},
+		parallelism: 1,
+		refs:        make(map[string]string, len(doc.Validators)),
+	}
+	for _, v := range doc.Validators {
+		if v.Ref == "" {
+			return nil, ErrInvalidKeyChainDocument{Reason: fmt.Sprintf("validator %q has no ref", v.ID)}
+		}
+		fn, err := lookupValidator[T](v.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if err := kc.SetValidator(Status{ID: v.ID, Details: v.Details}, fn); err != nil {
+			return nil, err
+		}
+		kc.refs[v.ID] = v.Ref
+	}
+	return kc, nil
+}
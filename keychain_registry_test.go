@@ -0,0 +1,111 @@
+package keycheck_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/colduction/keycheck-go"
+)
+
+func nonEmpty(s string) (bool, error) {
+	if s != "" {
+		return true, nil
+	}
+	return false, errors.New("must not be empty")
+}
+
+// yamlV2StyleUnmarshal stands in for gopkg.in/yaml.v2's Unmarshal, which
+// decodes mappings into map[interface{}]interface{} rather than the
+// map[string]interface{} encoding/json produces. It ignores data and
+// always decodes the same document, which is enough to exercise
+// LoadKeyChain's json.Marshal round-trip against an interface-keyed map.
+func yamlV2StyleUnmarshal(data []byte, v any) error {
+	out, ok := v.(*any)
+	if !ok {
+		return fmt.Errorf("yamlV2StyleUnmarshal: unexpected target type %T", v)
+	}
+	*out = map[interface{}]interface{}{
+		"condition": "AND",
+		"validators": []interface{}{
+			map[interface{}]interface{}{
+				"id":      "nonempty",
+				"ref":     "strings.NonEmpty",
+				"details": "must not be empty",
+			},
+		},
+	}
+	return nil
+}
+
+func TestLoadKeyChain(t *testing.T) {
+	keycheck.RegisterValidator[string]("strings.NonEmpty", nonEmpty)
+
+	t.Run("Valid Document", func(t *testing.T) {
+		doc := []byte(`{"condition":"AND","validators":[{"id":"nonempty","ref":"strings.NonEmpty","details":"must not be empty"}]}`)
+		kc, err := keycheck.LoadKeyChain[string](doc, json.Unmarshal)
+		if err != nil {
+			t.Fatalf("LoadKeyChain returned an error: %v", err)
+		}
+
+		label, ok, _ := kc.Validate("hello", keycheck.FAIL)
+		if !ok || label.GetID() != "nonempty" {
+			t.Errorf("expected label 'nonempty' and success, got label=%v ok=%v", label, ok)
+		}
+
+		_, ok, errs := kc.Validate("", keycheck.FAIL)
+		if ok || len(errs) != 1 {
+			t.Errorf("expected failure with 1 error for empty input, got ok=%v errs=%v", ok, errs)
+		}
+	})
+
+	t.Run("Interface-keyed map (yaml.v2-style)", func(t *testing.T) {
+		kc, err := keycheck.LoadKeyChain[string]([]byte("irrelevant"), yamlV2StyleUnmarshal)
+		if err != nil {
+			t.Fatalf("LoadKeyChain returned an error: %v", err)
+		}
+
+		label, ok, _ := kc.Validate("hello", keycheck.FAIL)
+		if !ok || label.GetID() != "nonempty" {
+			t.Errorf("expected label 'nonempty' and success, got label=%v ok=%v", label, ok)
+		}
+	})
+
+	t.Run("Unknown Ref", func(t *testing.T) {
+		doc := []byte(`{"condition":"OR","validators":[{"id":"x","ref":"does.not.exist"}]}`)
+		if _, err := keycheck.LoadKeyChain[string](doc, json.Unmarshal); err == nil {
+			t.Error("expected an error for an unresolved ref")
+		}
+	})
+
+	t.Run("Unknown Condition", func(t *testing.T) {
+		doc := []byte(`{"condition":"NAND","validators":[]}`)
+		if _, err := keycheck.LoadKeyChain[string](doc, json.Unmarshal); err == nil {
+			t.Error("expected an error for an unknown condition")
+		}
+	})
+}
+
+func TestKeyChain_Marshal(t *testing.T) {
+	keycheck.RegisterValidator[string]("strings.NonEmpty", nonEmpty)
+
+	doc := []byte(`{"condition":"AND","validators":[{"id":"nonempty","ref":"strings.NonEmpty","details":"must not be empty"}]}`)
+	kc, err := keycheck.LoadKeyChain[string](doc, json.Unmarshal)
+	if err != nil {
+		t.Fatalf("LoadKeyChain returned an error: %v", err)
+	}
+
+	out, err := kc.Marshal(json.Marshal)
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+
+	var roundTripped map[string]any
+	if err := json.Unmarshal(out, &roundTripped); err != nil {
+		t.Fatalf("Marshal produced invalid JSON: %v", err)
+	}
+	if roundTripped["condition"] != "AND" {
+		t.Errorf("expected condition 'AND', got %v", roundTripped["condition"])
+	}
+}
@@ -0,0 +1,332 @@
+package keycheck
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	ErrInvalidStructValidatorTarget struct{}
+	ErrUnknownTagValidator          struct{ Name string }
+)
+
+func (err ErrInvalidStructValidatorTarget) Error() string {
+	return "keycheck: StructValidator.Validate requires a struct or a pointer to a struct"
+}
+
+func (err ErrUnknownTagValidator) Error() string {
+	return fmt.Sprintf("keycheck: unknown tag validator %q", err.Name)
+}
+
+// TagValidatorFunc builds a field validator from a tag parameter. For
+// `keycheck:"min=3"`, RegisterTagValidator("min", fn) is called once at
+// registration time, and fn("3") is called each time a field carrying
+// that rule is validated, to produce the func(any) (bool, error) actually
+// run against the field's value.
+type TagValidatorFunc func(param string) func(a any) (bool, error)
+
+// tagValidatorRegistry holds every validator registered via
+// RegisterTagValidator, keyed by the name used in a `keycheck:"..."` tag.
+var tagValidatorRegistry sync.Map // map[string]TagValidatorFunc
+
+// RegisterTagValidator registers fn under name so a `keycheck:"..."`
+// struct tag can reference it, e.g. RegisterTagValidator("min", ...)
+// resolves the "min" in `keycheck:"min=3"`. Registering under a name
+// already in use replaces the previous entry.
+func RegisterTagValidator(name string, fn TagValidatorFunc) {
+	tagValidatorRegistry.Store(name, fn)
+}
+
+// tagAliasRegistry holds every alias registered via RegisterAlias, keyed
+// by the alias name.
+var tagAliasRegistry sync.Map // map[string]string, alias -> pipe-separated expansion
+
+// RegisterAlias registers alias as shorthand for expansion, a
+// pipe-separated list of tag validator rules, e.g.
+// RegisterAlias("iscolor", "hexcolor|rgb|rgba|hsl|hsla") lets a field use
+// `keycheck:"iscolor"` instead of spelling out the alternatives with "or".
+func RegisterAlias(alias, expansion string) {
+	tagAliasRegistry.Store(alias, expansion)
+}
+
+func init() {
+	RegisterTagValidator("required", func(string) func(a any) (bool, error) {
+		return func(a any) (bool, error) {
+			if isZeroValue(a) {
+				return false, errors.New("value is required")
+			}
+			return true, nil
+		}
+	})
+	RegisterTagValidator("min", func(param string) func(a any) (bool, error) {
+		n, _ := strconv.Atoi(param)
+		return func(a any) (bool, error) {
+			l, ok := lengthOrValueOf(a)
+			if !ok {
+				return false, fmt.Errorf("min: unsupported type %T", a)
+			}
+			if l < n {
+				return false, fmt.Errorf("value %d is less than min %d", l, n)
+			}
+			return true, nil
+		}
+	})
+	RegisterTagValidator("max", func(param string) func(a any) (bool, error) {
+		n, _ := strconv.Atoi(param)
+		return func(a any) (bool, error) {
+			l, ok := lengthOrValueOf(a)
+			if !ok {
+				return false, fmt.Errorf("max: unsupported type %T", a)
+			}
+			if l > n {
+				return false, fmt.Errorf("value %d exceeds max %d", l, n)
+			}
+			return true, nil
+		}
+	})
+}
+
+func isZeroValue(a any) bool {
+	v := reflect.ValueOf(a)
+	return !v.IsValid() || v.IsZero()
+}
+
+// lengthOrValueOf returns the length of a's string/slice/map/array, or
+// a's own value for numeric kinds, so a single "min"/"max" tag validator
+// covers both "at least N chars" and "at least N" cases.
+func lengthOrValueOf(a any) (int, bool) {
+	v := reflect.ValueOf(a)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int(v.Float()), true
+	default:
+		return 0, false
+	}
+}
+
+// FieldError reports why a single struct field failed validation.
+type FieldError struct {
+	FieldPath string
+	Status    Status
+	Errs      []error
+}
+
+// StructValidationError is the structured result of a failed
+// StructValidator.Validate call, one FieldError per failing field.
+type StructValidationError []FieldError
+
+func (err StructValidationError) Error() string {
+	if len(err) == 0 {
+		return "keycheck: struct validation failed"
+	}
+	return fmt.Sprintf("keycheck: struct validation failed for %d field(s), starting with %q", len(err), err[0].FieldPath)
+}
+
+// StructValidator walks a struct via reflection and, for each field
+// tagged `keycheck:"..."`, builds a per-field KeyChain[any] expression
+// tree out of the comma-separated rules (see RegisterTagValidator and
+// RegisterAlias), diving into nested structs, slices and maps. It is
+// stateless and safe for concurrent use.
+type StructValidator struct{}
+
+// NewStructValidator creates a StructValidator.
+func NewStructValidator() *StructValidator {
+	return &StructValidator{}
+}
+
+// Validate validates v, which must be a struct or a non-nil pointer to
+// one, returning every failing field's FieldError and whether v passed
+// as a whole.
+func (sv *StructValidator) Validate(v any) (StructValidationError, bool) {
+	rv := dereference(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return StructValidationError{{Status: Status{ID: "INVALID"}, Errs: []error{ErrInvalidStructValidatorTarget{}}}}, false
+	}
+	var errs StructValidationError
+	walkStruct("", rv, &errs)
+	return errs, len(errs) == 0
+}
+
+// dereference unwraps pointers and interfaces until it reaches a
+// concrete value, or an invalid/nil Value if it bottoms out on one.
+func dereference(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func walkStruct(path string, rv reflect.Value, out *StructValidationError) {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		fieldPath := sf.Name
+		if path != "" {
+			fieldPath = path + "." + sf.Name
+		}
+
+		if tag, ok := sf.Tag.Lookup("keycheck"); ok && tag != "" && tag != "-" {
+			if status, errs, failed := validateTaggedField(tag, fv, rv); failed {
+				*out = append(*out, FieldError{FieldPath: fieldPath, Status: status, Errs: errs})
+			}
+		}
+
+		diveInto(fieldPath, dereference(fv), out)
+	}
+}
+
+func diveInto(path string, v reflect.Value, out *StructValidationError) {
+	switch v.Kind() {
+	case reflect.Struct:
+		walkStruct(path, v, out)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			diveInto(fmt.Sprintf("%s[%d]", path, i), dereference(v.Index(i)), out)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			diveInto(fmt.Sprintf("%s[%v]", path, key.Interface()), dereference(v.MapIndex(key)), out)
+		}
+	}
+}
+
+// validateTaggedField builds an AND of every comma-separated rule in tag
+// into a KeyChain[any] expression tree and validates fv's value against
+// it, returning the Status to report, any errors, and whether the field
+// failed.
+func validateTaggedField(tag string, fv reflect.Value, parent reflect.Value) (Status, []error, bool) {
+	segments := strings.Split(tag, ",")
+	children := make([]Expr, 0, len(segments))
+	leaves := make(map[string]func(a any) (bool, error), len(segments))
+	for i, seg := range segments {
+		seg = strings.TrimSpace(seg)
+		if seg == "" {
+			continue
+		}
+		fn, err := buildRuleValidator(seg, parent)
+		if err != nil {
+			return Status{ID: "INVALID"}, []error{err}, true
+		}
+		id := fmt.Sprintf("rule%d", i)
+		leaves[id] = fn
+		children = append(children, Ref(id))
+	}
+	if len(children) == 0 {
+		return Status{}, nil, false
+	}
+
+	var expr Expr = And(children...)
+	if len(children) == 1 {
+		expr = children[0]
+	}
+	kc, err := NewKeyChainExpr[any](expr)
+	if err != nil {
+		return Status{ID: "INVALID"}, []error{err}, true
+	}
+	for id, fn := range leaves {
+		_ = kc.SetValidator(Status{ID: id}, fn)
+	}
+
+	_, ok, errs := kc.Validate(fv.Interface(), INVALID)
+	if ok {
+		return Status{}, nil, false
+	}
+	return Status{ID: "INVALID"}, errs, true
+}
+
+// buildRuleValidator resolves a single rule segment, such as "min=3",
+// "or=hexcolor|rgb", "eqfield=Other" or a registered alias, into a
+// func(any) (bool, error) that can be used as a KeyChain leaf validator.
+func buildRuleValidator(seg string, parent reflect.Value) (func(a any) (bool, error), error) {
+	name, param, hasParam := strings.Cut(seg, "=")
+	switch name {
+	case "eqfield":
+		if !hasParam {
+			return nil, fmt.Errorf("keycheck: eqfield requires a target field name")
+		}
+		return buildEqFieldValidator(param, parent), nil
+	case "or":
+		if !hasParam {
+			return nil, fmt.Errorf("keycheck: or requires pipe-separated alternatives")
+		}
+		return buildOrValidator(param, parent)
+	}
+	if expansion, ok := tagAliasRegistry.Load(name); ok {
+		return buildOrValidator(expansion.(string), parent)
+	}
+	return resolveTagValidator(name, param)
+}
+
+func resolveTagValidator(name, param string) (func(a any) (bool, error), error) {
+	v, ok := tagValidatorRegistry.Load(name)
+	if !ok {
+		return nil, ErrUnknownTagValidator{Name: name}
+	}
+	return v.(TagValidatorFunc)(param), nil
+}
+
+// buildOrValidator combines every pipe-separated alternative rule in
+// alternatives into a single validator via a nested KeyChain[any] in OR
+// mode: the field passes if any alternative does.
+func buildOrValidator(alternatives string, parent reflect.Value) (func(a any) (bool, error), error) {
+	names := strings.Split(alternatives, "|")
+	kc, err := NewKeyChain[any](OR)
+	if err != nil {
+		return nil, err
+	}
+	for i, n := range names {
+		fn, err := buildRuleValidator(strings.TrimSpace(n), parent)
+		if err != nil {
+			return nil, err
+		}
+		if err := kc.SetValidator(Status{ID: fmt.Sprintf("alt%d", i)}, fn); err != nil {
+			return nil, err
+		}
+	}
+	return func(a any) (bool, error) {
+		_, ok, errs := kc.Validate(a, FAIL)
+		if ok {
+			return true, nil
+		}
+		return false, errors.Join(errs...)
+	}, nil
+}
+
+// buildEqFieldValidator returns a validator that succeeds when the
+// tagged field's value equals the sibling field named fieldName on
+// parent, the struct the tagged field belongs to.
+func buildEqFieldValidator(fieldName string, parent reflect.Value) func(a any) (bool, error) {
+	return func(a any) (bool, error) {
+		if parent.Kind() != reflect.Struct {
+			return false, fmt.Errorf("eqfield: parent is not a struct")
+		}
+		other := parent.FieldByName(fieldName)
+		if !other.IsValid() {
+			return false, fmt.Errorf("eqfield: field %q not found", fieldName)
+		}
+		if !other.CanInterface() {
+			return false, fmt.Errorf("eqfield: field %q is unexported", fieldName)
+		}
+		if reflect.DeepEqual(a, other.Interface()) {
+			return true, nil
+		}
+		return false, fmt.Errorf("must equal field %s", fieldName)
+	}
+}
@@ -0,0 +1,156 @@
+package keycheck_test
+
+import (
+	"testing"
+
+	"github.com/colduction/keycheck-go"
+)
+
+type address struct {
+	City string `keycheck:"required"`
+}
+
+type person struct {
+	Name      string `keycheck:"required,min=2"`
+	Password  string `keycheck:"min=3"`
+	Confirm   string `keycheck:"eqfield=Password"`
+	Addresses []address
+}
+
+func TestStructValidator_Validate(t *testing.T) {
+	t.Run("Valid Struct", func(t *testing.T) {
+		sv := keycheck.NewStructValidator()
+		p := person{
+			Name:      "Al",
+			Password:  "hunter2",
+			Confirm:   "hunter2",
+			Addresses: []address{{City: "Berlin"}},
+		}
+		errs, ok := sv.Validate(p)
+		if !ok {
+			t.Fatalf("expected a valid struct, got errors: %+v", errs)
+		}
+	})
+
+	t.Run("Required And Min Length", func(t *testing.T) {
+		sv := keycheck.NewStructValidator()
+		p := person{Name: "", Password: "x", Confirm: "x"}
+		errs, ok := sv.Validate(p)
+		if ok {
+			t.Fatal("expected validation to fail for an empty required field")
+		}
+		found := false
+		for _, e := range errs {
+			if e.FieldPath == "Name" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a FieldError for 'Name', got %+v", errs)
+		}
+	})
+
+	t.Run("Cross-Field eqfield", func(t *testing.T) {
+		sv := keycheck.NewStructValidator()
+		p := person{Name: "Al", Password: "hunter2", Confirm: "different"}
+		errs, ok := sv.Validate(p)
+		if ok {
+			t.Fatal("expected validation to fail when Confirm does not equal Password")
+		}
+		found := false
+		for _, e := range errs {
+			if e.FieldPath == "Confirm" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a FieldError for 'Confirm', got %+v", errs)
+		}
+	})
+
+	t.Run("Dive Into Nested Struct Slice", func(t *testing.T) {
+		sv := keycheck.NewStructValidator()
+		p := person{
+			Name:      "Al",
+			Password:  "hunter2",
+			Confirm:   "hunter2",
+			Addresses: []address{{City: ""}},
+		}
+		errs, ok := sv.Validate(p)
+		if ok {
+			t.Fatal("expected validation to fail for a nested required field")
+		}
+		found := false
+		for _, e := range errs {
+			if e.FieldPath == "Addresses[0].City" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a FieldError for 'Addresses[0].City', got %+v", errs)
+		}
+	})
+
+	t.Run("Alias Expands To Or", func(t *testing.T) {
+		keycheck.RegisterTagValidator("hex", func(string) func(a any) (bool, error) {
+			return func(a any) (bool, error) {
+				s, _ := a.(string)
+				if len(s) == 6 {
+					return true, nil
+				}
+				return false, nil
+			}
+		})
+		keycheck.RegisterTagValidator("rgbword", func(string) func(a any) (bool, error) {
+			return func(a any) (bool, error) {
+				if a == "red" || a == "green" || a == "blue" {
+					return true, nil
+				}
+				return false, nil
+			}
+		})
+		keycheck.RegisterAlias("iscolor", "hex|rgbword")
+
+		type swatch struct {
+			Color string `keycheck:"iscolor"`
+		}
+		sv := keycheck.NewStructValidator()
+		if _, ok := sv.Validate(swatch{Color: "blue"}); !ok {
+			t.Error("expected 'blue' to satisfy the iscolor alias via rgbword")
+		}
+		if _, ok := sv.Validate(swatch{Color: "ff00aa"}); !ok {
+			t.Error("expected a 6-char string to satisfy the iscolor alias via hex")
+		}
+		if _, ok := sv.Validate(swatch{Color: "nope"}); ok {
+			t.Error("expected an invalid color to fail validation")
+		}
+	})
+
+	t.Run("eqfield Against Unexported Sibling", func(t *testing.T) {
+		type secretPair struct {
+			secret string
+			Public string `keycheck:"eqfield=secret"`
+		}
+		sv := keycheck.NewStructValidator()
+		errs, ok := sv.Validate(secretPair{secret: "hunter2", Public: "hunter2"})
+		if ok {
+			t.Fatalf("expected validation to fail for an unexported eqfield target, got errors: %+v", errs)
+		}
+		found := false
+		for _, e := range errs {
+			if e.FieldPath == "Public" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a FieldError for 'Public', got %+v", errs)
+		}
+	})
+
+	t.Run("Non-Struct Target", func(t *testing.T) {
+		sv := keycheck.NewStructValidator()
+		if _, ok := sv.Validate(42); ok {
+			t.Error("expected validation of a non-struct to fail")
+		}
+	})
+}
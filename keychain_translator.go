@@ -0,0 +1,179 @@
+package keycheck
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrNilTranslator is returned by NewKeyChainWithTranslator when passed a
+// nil Translator.
+type ErrNilTranslator struct{}
+
+func (err ErrNilTranslator) Error() string {
+	return "keycheck: translator is nil"
+}
+
+// Translator resolves a localized, parameterized message for a message
+// key, in the spirit of the translator interfaces used by the
+// go-playground/universal-translator ecosystem. Implementations decide
+// how params are substituted into the resolved text.
+type Translator interface {
+	T(key string, params ...any) string
+}
+
+// MapTranslator is a simple in-memory Translator. Templates are
+// registered per locale with Register, and placeholders in a template
+// use positional markers {0}, {1}, ... that are replaced with
+// fmt.Sprint of the matching param.
+type MapTranslator struct {
+	mu        sync.RWMutex
+	templates map[string]map[string]string // locale -> key -> template
+}
+
+// NewMapTranslator creates an empty MapTranslator ready for Register calls.
+func NewMapTranslator() *MapTranslator {
+	return &MapTranslator{templates: map[string]map[string]string{}}
+}
+
+// Register associates template with key under locale, replacing any
+// template previously registered under the same locale and key.
+func (m *MapTranslator) Register(locale, key, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.templates[locale] == nil {
+		m.templates[locale] = map[string]string{}
+	}
+	m.templates[locale][key] = template
+}
+
+// WithLocale returns a Translator bound to locale. Looking up a key with
+// no template registered under locale returns the key itself, so a
+// missing translation degrades to something still identifiable instead
+// of an empty string.
+func (m *MapTranslator) WithLocale(locale string) Translator {
+	return mapTranslatorLocale{m: m, locale: locale}
+}
+
+type mapTranslatorLocale struct {
+	m      *MapTranslator
+	locale string
+}
+
+func (l mapTranslatorLocale) T(key string, params ...any) string {
+	l.m.mu.RLock()
+	template, ok := l.m.templates[l.locale][key]
+	l.m.mu.RUnlock()
+	if !ok {
+		return key
+	}
+	for i, p := range params {
+		template = strings.ReplaceAll(template, fmt.Sprintf("{%d}", i), fmt.Sprint(p))
+	}
+	return template
+}
+
+// message pairs a translator key with an extractor that turns the data
+// being validated into the key's template params.
+type message[T any] struct {
+	key    string
+	params func(a T) []any
+}
+
+// TranslatedKeyChain is a KeyChain[T] that additionally supports
+// registering a localized message for a validator, so failing Validate
+// calls return a StatusGetter with a populated, human-readable Details.
+type TranslatedKeyChain[T any] interface {
+	KeyChain[T]
+	SetValidatorMessage(status Status, fn func(a T) (bool, error), key string, params func(a T) []any) error
+}
+
+type keyChainTranslated[T any] struct {
+	*keyChain[T]
+	translator Translator
+	messagesMu sync.RWMutex
+	messages   map[string]message[T]
+}
+
+// NewKeyChainWithTranslator creates a TranslatedKeyChain[T] governed by
+// condition, whose Validate calls resolve Details through translator
+// whenever a validator registered via SetValidatorMessage causes the
+// overall result to fail.
+func NewKeyChainWithTranslator[T any](condition BitwiseID, translator Translator) (TranslatedKeyChain[T], error) {
+	if !condition.IsValid() {
+		return nil, ErrInvalidBitwiseID(condition)
+	}
+	if translator == nil {
+		return nil, ErrNilTranslator{}
+	}
+	return &keyChainTranslated[T]{
+		keyChain: &keyChain[T]{
+			validators:  validatorsMap[T]{},
+			condition:   condition,
+			order:       []string{},
+			parallelism: 1,
+		},
+		translator: translator,
+		messages:   map[string]message[T]{},
+	}, nil
+}
+
+// SetValidatorMessage registers fn under status, exactly like
+// SetValidator, plus a translator message key and a params extractor
+// used to populate Details whenever fn is responsible for a failing
+// Validate result.
+func (kc *keyChainTranslated[T]) SetValidatorMessage(status Status, fn func(a T) (bool, error), key string, params func(a T) []any) error {
+	if kc == nil || kc.keyChain == nil {
+		return ErrNilReceiver{}
+	}
+	if err := kc.keyChain.SetValidator(status, fn); err != nil {
+		return err
+	}
+	kc.messagesMu.Lock()
+	defer kc.messagesMu.Unlock()
+	if kc.messages == nil {
+		kc.messages = map[string]message[T]{}
+	}
+	kc.messages[status.ID] = message[T]{key: key, params: params}
+	return nil
+}
+
+// translate clones defaultStatus and, if id has a message registered via
+// SetValidatorMessage, populates the clone's Details from the
+// keychain's Translator. If id has no message, defaultStatus is
+// returned unchanged.
+func (kc *keyChainTranslated[T]) translate(defaultStatus StatusGetter, id string, data T) StatusGetter {
+	kc.messagesMu.RLock()
+	m, ok := kc.messages[id]
+	kc.messagesMu.RUnlock()
+	if !ok {
+		return defaultStatus
+	}
+	var params []any
+	if m.params != nil {
+		params = m.params(data)
+	}
+	clone := defaultStatus.Clone()
+	clone.SetDetails(kc.translator.T(m.key, params...))
+	return &clone
+}
+
+// Validate processes data exactly like keyChain.Validate, except that
+// whenever AND, OR or XOR settles on defaultStatus because of a specific
+// failing validator, translate is consulted for that validator's message
+// so the returned StatusGetter carries a localized Details string
+// instead of an empty one. NOT has no single failing validator to
+// attribute a message to, so it behaves exactly like keyChain.Validate.
+func (kc *keyChainTranslated[T]) Validate(data T, defaultStatus StatusGetter) (StatusGetter, bool, []error) {
+	if kc == nil || kc.keyChain == nil {
+		return nil, false, []error{ErrNilReceiver{}}
+	}
+	kc.keyChain.mu.RLock()
+	defer kc.keyChain.mu.RUnlock()
+	if kc.keyChain.validators == nil {
+		return defaultStatus, false, nil
+	}
+	return validateSequential(kc.keyChain.condition, kc.keyChain.validators, kc.keyChain.order, data, defaultStatus, func(id string) StatusGetter {
+		return kc.translate(defaultStatus, id, data)
+	})
+}
@@ -0,0 +1,66 @@
+package keycheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/colduction/keycheck-go"
+)
+
+func TestNewKeyChainWithTranslator(t *testing.T) {
+	t.Run("Nil Translator", func(t *testing.T) {
+		if _, err := keycheck.NewKeyChainWithTranslator[string](keycheck.AND, nil); err == nil {
+			t.Error("NewKeyChainWithTranslator with a nil translator should return an error")
+		}
+	})
+}
+
+func TestKeyChainTranslated_Validate(t *testing.T) {
+	tr := keycheck.NewMapTranslator()
+	tr.Register("en", "field.min_length", "field {0} must be at least {1} chars")
+
+	kc, err := keycheck.NewKeyChainWithTranslator[string](keycheck.AND, tr.WithLocale("en"))
+	if err != nil {
+		t.Fatalf("NewKeyChainWithTranslator returned an error: %v", err)
+	}
+
+	minLength := func(s string) (bool, error) {
+		if len(s) >= 3 {
+			return true, nil
+		}
+		return false, errors.New("too short")
+	}
+	err = kc.SetValidatorMessage(keycheck.Status{ID: "min3"}, minLength, "field.min_length", func(s string) []any {
+		return []any{"name", 3}
+	})
+	if err != nil {
+		t.Fatalf("SetValidatorMessage returned an error: %v", err)
+	}
+
+	status, ok, errs := kc.Validate("ab", keycheck.FAIL)
+	if ok {
+		t.Fatal("expected validation to fail")
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %d", len(errs))
+	}
+	want := "field name must be at least 3 chars"
+	if got := status.GetDetails(); got != want {
+		t.Errorf("expected Details %q, got %q", want, got)
+	}
+
+	status, ok, _ = kc.Validate("abcdef", keycheck.FAIL)
+	if !ok {
+		t.Error("expected validation to succeed for a long enough string")
+	}
+	if status.GetDetails() != "" {
+		t.Errorf("expected empty Details on success, got %q", status.GetDetails())
+	}
+}
+
+func TestMapTranslator_MissingTemplate(t *testing.T) {
+	tr := keycheck.NewMapTranslator()
+	if got := tr.WithLocale("en").T("unregistered.key"); got != "unregistered.key" {
+		t.Errorf("expected the key itself when no template is registered, got %q", got)
+	}
+}
@@ -1,9 +1,12 @@
 package keycheck
 
+import "context"
+
 type (
 	validatorKey[T any] struct {
-		status    Status
-		validator func(a T) (bool, error)
+		status       Status
+		validator    func(a T) (bool, error)
+		validatorCtx func(ctx context.Context, a T) (bool, error)
 	}
 	validatorsMap[T any] map[string]validatorKey[T]
 )
@@ -15,6 +18,26 @@ func (m validatorsMap[T]) Get(id string) (Status, func(a T) (bool, error)) {
 	return Status{}, nil
 }
 
+// GetContext retrieves the context-aware validator registered for id via
+// SetContext. If only a plain validator was registered for id, it is
+// adapted into a context-aware one that ignores cancellation.
+func (m validatorsMap[T]) GetContext(id string) (Status, func(ctx context.Context, a T) (bool, error)) {
+	d, ok := m[id]
+	if !ok {
+		return Status{}, nil
+	}
+	if d.validatorCtx != nil {
+		return d.status, d.validatorCtx
+	}
+	if d.validator == nil {
+		return d.status, nil
+	}
+	fn := d.validator
+	return d.status, func(ctx context.Context, a T) (bool, error) {
+		return fn(a)
+	}
+}
+
 func (m validatorsMap[T]) Set(id Status, fn func(a T) (bool, error)) {
 	m[id.ID] = validatorKey[T]{
 		status:    id,
@@ -22,6 +45,23 @@ func (m validatorsMap[T]) Set(id Status, fn func(a T) (bool, error)) {
 	}
 }
 
+// SetContext registers a context-aware validator function for id,
+// replacing any validator (plain or context-aware) previously set. Get
+// returns an adapter for it that calls fn with context.Background().
+func (m validatorsMap[T]) SetContext(id Status, fn func(ctx context.Context, a T) (bool, error)) {
+	var plain func(a T) (bool, error)
+	if fn != nil {
+		plain = func(a T) (bool, error) {
+			return fn(context.Background(), a)
+		}
+	}
+	m[id.ID] = validatorKey[T]{
+		status:       id,
+		validator:    plain,
+		validatorCtx: fn,
+	}
+}
+
 func (m validatorsMap[T]) Del(id string) {
 	delete(m, id)
 }